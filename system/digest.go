@@ -0,0 +1,92 @@
+package system
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// digestAlgorithms are the algorithms Digest knows how to compute, keyed
+// by the algo name Digest accepts ("md5", "sha1", ..., "blake2b-256").
+var digestAlgorithms = map[string]func() hash.Hash{
+	"md5":         md5.New,
+	"sha1":        sha1.New,
+	"sha256":      sha256.New,
+	"sha512":      sha512.New,
+	"blake2b-256": newBlake2b256,
+	"crc32":       newCRC32,
+}
+
+func newBlake2b256() hash.Hash {
+	h, _ := blake2b.New256(nil)
+	return h
+}
+
+func newCRC32() hash.Hash {
+	return crc32.NewIEEE()
+}
+
+// Digest returns the hex digest of the file at f.path for algo ("md5",
+// "sha1", "sha256", "sha512", "blake2b-256", or "crc32").
+//
+// The resource layer validates a file one field at a time, so Md5() and
+// Sha256() on the same path are two separate calls with no shared
+// context between them. To still only stream the file once, the first
+// call to Digest (via Md5, Sha256, or Digest itself) computes every
+// algorithm in digestAlgorithms in a single pass and caches them all;
+// every later call, for any algorithm, is then a cache hit.
+func (f *DefFile) Digest(algo string) (string, error) {
+	if err := f.setup(); err != nil {
+		return "", err
+	}
+
+	if _, ok := digestAlgorithms[algo]; !ok {
+		return "", fmt.Errorf("%s: unknown digest algorithm", algo)
+	}
+
+	if err := f.computeDigests(); err != nil {
+		return "", err
+	}
+	return f.digests[algo], nil
+}
+
+// computeDigests streams the file once, fanning a single io.Copy out
+// into an io.MultiWriter over every supported hash algorithm, and caches
+// every result on f.digests. It's a no-op once f.digests is populated.
+func (f *DefFile) computeDigests() error {
+	if f.digests != nil {
+		return nil
+	}
+
+	fh, err := f.fsys.Open(f.fsPath)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	hashes := make(map[string]hash.Hash, len(digestAlgorithms))
+	writers := make([]io.Writer, 0, len(digestAlgorithms))
+	for name, newHash := range digestAlgorithms {
+		h := newHash()
+		hashes[name] = h
+		writers = append(writers, h)
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), fh); err != nil {
+		return err
+	}
+
+	digests := make(map[string]string, len(hashes))
+	for name, h := range hashes {
+		digests[name] = fmt.Sprintf("%x", h.Sum(nil))
+	}
+	f.digests = digests
+	return nil
+}
@@ -0,0 +1,101 @@
+package system
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+func wantDigest(t *testing.T, algo string) string {
+	t.Helper()
+	switch algo {
+	case "md5":
+		return fmt.Sprintf("%x", md5.Sum([]byte(content)))
+	case "sha1":
+		return fmt.Sprintf("%x", sha1.Sum([]byte(content)))
+	case "sha256":
+		return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+	case "sha512":
+		return fmt.Sprintf("%x", sha512.Sum512([]byte(content)))
+	case "blake2b-256":
+		sum := blake2b.Sum256([]byte(content))
+		return fmt.Sprintf("%x", sum)
+	case "crc32":
+		sum := crc32.ChecksumIEEE([]byte(content))
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], sum)
+		return fmt.Sprintf("%x", b)
+	}
+	t.Fatalf("wantDigest: unknown algo %q", algo)
+	return ""
+}
+
+func TestDigestAllAlgorithms(t *testing.T) {
+	fsys := NewMapFS(map[string]*MapFile{
+		"tmp/file.txt": {Data: []byte(content), Mode: 0644},
+	})
+	f := newTestFile(t, fsys, "/tmp/file.txt")
+
+	for algo := range digestAlgorithms {
+		got, err := f.Digest(algo)
+		if err != nil {
+			t.Errorf("Digest(%q) returned an error: %v", algo, err)
+			continue
+		}
+		if want := wantDigest(t, algo); got != want {
+			t.Errorf("Digest(%q) = %q, want %q", algo, got, want)
+		}
+	}
+}
+
+func TestDigestUnknownAlgorithm(t *testing.T) {
+	fsys := NewMapFS(map[string]*MapFile{
+		"tmp/file.txt": {Data: []byte(content), Mode: 0644},
+	})
+	f := newTestFile(t, fsys, "/tmp/file.txt")
+
+	if _, err := f.Digest("md17"); err == nil {
+		t.Error(`Digest("md17") = nil error, want an error`)
+	}
+}
+
+// countingFS wraps an FS and counts calls to Open, so tests can assert
+// on how many times a file was actually read.
+type countingFS struct {
+	FS
+	opens int
+}
+
+func (c *countingFS) Open(name string) (fs.File, error) {
+	c.opens++
+	return c.FS.Open(name)
+}
+
+func TestDigestSinglePass(t *testing.T) {
+	base := NewMapFS(map[string]*MapFile{
+		"tmp/file.txt": {Data: []byte(content), Mode: 0644},
+	})
+	fsys := &countingFS{FS: base}
+	f := newTestFile(t, fsys, "/tmp/file.txt")
+
+	// Mirrors how the resource layer validates a file with both md5:
+	// and sha256: set: one independent call per field.
+	if _, err := f.Md5(); err != nil {
+		t.Fatalf("Md5() = %v", err)
+	}
+	if _, err := f.Sha256(); err != nil {
+		t.Fatalf("Sha256() = %v", err)
+	}
+
+	if fsys.opens != 1 {
+		t.Errorf("Md5() followed by Sha256() opened the file %d times, want 1", fsys.opens)
+	}
+}
@@ -0,0 +1,12 @@
+// Package system implements the File and Service backends goss checks
+// against: the host filesystem and D-Bus, plus the tar/zip/map/9P
+// alternatives used for testing and for validating an image or a remote
+// target without a shell on it.
+//
+// Several requests against this package describe CLI flags or
+// resource/matcher-layer behavior ("goss serve --9p", a "--user" flag,
+// an RFC3339/duration matcher for AccessTime/ChangeTime): none of that
+// lives here. This package only provides the underlying File/Service
+// capability; wiring it up to a flag or a matcher belongs in cmd or the
+// resource package, and is out of scope for the changes in this package.
+package system
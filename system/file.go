@@ -1,15 +1,14 @@
 package system
 
 import (
-	"crypto/md5"
-	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"syscall"
+	"time"
 
 	"github.com/aelsabbahy/goss/util"
 	"github.com/opencontainers/runc/libcontainer/user"
@@ -27,22 +26,35 @@ type File interface {
 	LinkedTo() (string, error)
 	Md5() (string, error)
 	Sha256() (string, error)
+	// Digest returns the hex digest of the file for algo, one of "md5",
+	// "sha1", "sha256", "sha512", "blake2b-256", or "crc32".
+	Digest(algo string) (string, error)
+	ModTime() (time.Time, error)
+	AccessTime() (time.Time, error)
+	ChangeTime() (time.Time, error)
 }
 
 type DefFile struct {
+	fsys     FS
 	path     string
 	realPath string
-	fi       os.FileInfo
+	fsPath   string
+	fi       fs.FileInfo
 	loaded   bool
 	err      error
+	digests  map[string]string
 }
 
-func NewDefFile(path string, system *System, config util.Config) File {
+// NewDefFile builds a File that resolves path against fsys. Ordinary
+// goss runs pass hostFS, the live operating system; the tar/zip and 9P
+// backends pass a tarFS, zipFS, or ninepFS instead, so the same File
+// logic can assert on an archive or a remote target.
+func NewDefFile(fsys FS, path string, system *System, config util.Config) File {
 	if !strings.HasPrefix(path, "~") {
 		// FIXME: we probably shouldn't ignore errors here
 		path, _ = filepath.Abs(path)
 	}
-	return &DefFile{path: path}
+	return &DefFile{fsys: fsys, path: path}
 }
 
 func (f *DefFile) setup() error {
@@ -53,10 +65,25 @@ func (f *DefFile) setup() error {
 	if f.realPath, f.err = realPath(f.path); f.err != nil {
 		return f.err
 	}
+	f.fsPath = fsPath(f.realPath)
 
 	return f.err
 }
 
+// stat lazily stats the file on f.fsys, caching the result for the rest
+// of this DefFile's queries.
+func (f *DefFile) stat() (fs.FileInfo, error) {
+	if f.fi != nil {
+		return f.fi, nil
+	}
+	fi, err := statOf(f.fsys, f.fsPath)
+	if err != nil {
+		return nil, err
+	}
+	f.fi = fi
+	return fi, nil
+}
+
 func (f *DefFile) Path() string {
 	return f.path
 }
@@ -66,8 +93,8 @@ func (f *DefFile) Exists() (bool, error) {
 		return false, err
 	}
 
-	_, err := os.Lstat(f.realPath)
-	if os.IsNotExist(err) {
+	_, err := statOf(f.fsys, f.fsPath)
+	if errors.Is(err, fs.ErrNotExist) {
 		return false, nil
 	}
 	return true, err
@@ -78,7 +105,7 @@ func (f *DefFile) Contains() (io.Reader, error) {
 		return nil, err
 	}
 
-	fh, err := os.Open(f.realPath)
+	fh, err := f.fsys.Open(f.fsPath)
 	if err != nil {
 		return nil, err
 	}
@@ -90,15 +117,15 @@ func (f *DefFile) Mode() (string, error) {
 		return "", err
 	}
 
-	fi, err := os.Lstat(f.realPath)
+	fi, err := f.stat()
 	if err != nil {
 		return "", err
 	}
 
-	sys := fi.Sys()
-	stat := sys.(*syscall.Stat_t)
-	mode := fmt.Sprintf("%04o", (stat.Mode & 07777))
-	return mode, nil
+	if owner, ok := statOwner(fi); ok {
+		return fmt.Sprintf("%04o", owner.RawMode()&07777), nil
+	}
+	return fmt.Sprintf("%04o", fi.Mode().Perm()), nil
 }
 
 func (f *DefFile) Size() (int, error) {
@@ -106,13 +133,12 @@ func (f *DefFile) Size() (int, error) {
 		return 0, err
 	}
 
-	fi, err := os.Lstat(f.realPath)
+	fi, err := f.stat()
 	if err != nil {
 		return 0, err
 	}
 
-	size := fi.Size()
-	return int(size), nil
+	return int(fi.Size()), nil
 }
 
 func (f *DefFile) Filetype() (string, error) {
@@ -120,22 +146,22 @@ func (f *DefFile) Filetype() (string, error) {
 		return "", err
 	}
 
-	fi, err := os.Lstat(f.realPath)
+	fi, err := f.stat()
 	if err != nil {
 		return "", err
 	}
 
 	switch {
-	case fi.Mode()&os.ModeSymlink == os.ModeSymlink:
+	case fi.Mode()&fs.ModeSymlink == fs.ModeSymlink:
 		return "symlink", nil
-	case fi.Mode()&os.ModeDevice == os.ModeDevice:
-		if fi.Mode()&os.ModeCharDevice == os.ModeCharDevice {
+	case fi.Mode()&fs.ModeDevice == fs.ModeDevice:
+		if fi.Mode()&fs.ModeCharDevice == fs.ModeCharDevice {
 			return "character-device", nil
 		}
 		return "block-device", nil
-	case fi.Mode()&os.ModeNamedPipe == os.ModeNamedPipe:
+	case fi.Mode()&fs.ModeNamedPipe == fs.ModeNamedPipe:
 		return "pipe", nil
-	case fi.Mode()&os.ModeSocket == os.ModeSocket:
+	case fi.Mode()&fs.ModeSocket == fs.ModeSocket:
 		return "socket", nil
 	case fi.IsDir():
 		return "directory", nil
@@ -151,17 +177,16 @@ func (f *DefFile) Owner() (string, error) {
 		return "", err
 	}
 
-	fi, err := os.Lstat(f.realPath)
+	fi, err := f.stat()
 	if err != nil {
 		return "", err
 	}
 
-	uidS := fmt.Sprint(fi.Sys().(*syscall.Stat_t).Uid)
-	uid, err := strconv.Atoi(uidS)
-	if err != nil {
-		return "", err
+	owner, ok := statOwner(fi)
+	if !ok {
+		return "", fmt.Errorf("%s: owner information is not available on this filesystem", f.path)
 	}
-	return getUserForUid(uid)
+	return getUserForUid(owner.OwnerID())
 }
 
 func (f *DefFile) Group() (string, error) {
@@ -169,17 +194,16 @@ func (f *DefFile) Group() (string, error) {
 		return "", err
 	}
 
-	fi, err := os.Lstat(f.realPath)
+	fi, err := f.stat()
 	if err != nil {
 		return "", err
 	}
 
-	gidS := fmt.Sprint(fi.Sys().(*syscall.Stat_t).Gid)
-	gid, err := strconv.Atoi(gidS)
-	if err != nil {
-		return "", err
+	owner, ok := statOwner(fi)
+	if !ok {
+		return "", fmt.Errorf("%s: owner information is not available on this filesystem", f.path)
 	}
-	return getGroupForGid(gid)
+	return getGroupForGid(owner.GroupID())
 }
 
 func (f *DefFile) LinkedTo() (string, error) {
@@ -187,11 +211,51 @@ func (f *DefFile) LinkedTo() (string, error) {
 		return "", err
 	}
 
-	dst, err := os.Readlink(f.realPath)
+	return readLinkOf(f.fsys, f.fsPath)
+}
+
+func (f *DefFile) ModTime() (time.Time, error) {
+	if err := f.setup(); err != nil {
+		return time.Time{}, err
+	}
+
+	fi, err := f.stat()
 	if err != nil {
-		return "", err
+		return time.Time{}, err
+	}
+	return fi.ModTime(), nil
+}
+
+func (f *DefFile) AccessTime() (time.Time, error) {
+	if err := f.setup(); err != nil {
+		return time.Time{}, err
+	}
+
+	fi, err := f.stat()
+	if err != nil {
+		return time.Time{}, err
 	}
-	return dst, nil
+	ts, ok := statTime(fi)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s: access time is not available on this filesystem", f.path)
+	}
+	return ts.AccessTime(), nil
+}
+
+func (f *DefFile) ChangeTime() (time.Time, error) {
+	if err := f.setup(); err != nil {
+		return time.Time{}, err
+	}
+
+	fi, err := f.stat()
+	if err != nil {
+		return time.Time{}, err
+	}
+	ts, ok := statTime(fi)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s: change time is not available on this filesystem", f.path)
+	}
+	return ts.ChangeTime(), nil
 }
 
 func realPath(path string) (string, error) {
@@ -220,43 +284,11 @@ func realPath(path string) (string, error) {
 }
 
 func (f *DefFile) Md5() (string, error) {
-
-	if err := f.setup(); err != nil {
-		return "", err
-	}
-
-	fh, err := os.Open(f.realPath)
-	if err != nil {
-		return "", err
-	}
-	defer fh.Close()
-
-	hash := md5.New()
-	if _, err := io.Copy(hash, fh); err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+	return f.Digest("md5")
 }
 
 func (f *DefFile) Sha256() (string, error) {
-
-	if err := f.setup(); err != nil {
-		return "", err
-	}
-
-	fh, err := os.Open(f.realPath)
-	if err != nil {
-		return "", err
-	}
-	defer fh.Close()
-
-	hash := sha256.New()
-	if _, err := io.Copy(hash, fh); err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+	return f.Digest("sha256")
 }
 
 func getUserForUid(uid int) (string, error) {
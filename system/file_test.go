@@ -0,0 +1,206 @@
+package system
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/aelsabbahy/goss/util"
+)
+
+const content = "hello world"
+
+var (
+	wantMd5    = fmt.Sprintf("%x", md5.Sum([]byte(content)))
+	wantSha256 = fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+)
+
+func newTestFile(t *testing.T, fsys FS, path string) File {
+	t.Helper()
+	return NewDefFile(fsys, path, nil, util.Config{})
+}
+
+func TestDefFileMapFS(t *testing.T) {
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	fsys := NewMapFS(map[string]*MapFile{
+		"tmp/file.txt": {
+			Data:    []byte(content),
+			Mode:    0644,
+			ModTime: modTime,
+			Uid:     0,
+			Gid:     0,
+		},
+		"tmp/dir": {
+			Mode: fs.ModeDir | 0755,
+		},
+		"tmp/link": {
+			Mode: fs.ModeSymlink | 0777,
+			Link: "/tmp/file.txt",
+		},
+	})
+
+	f := newTestFile(t, fsys, "/tmp/file.txt")
+
+	if got, err := f.Mode(); err != nil || got != "0644" {
+		t.Errorf("Mode() = %q, %v, want 0644, nil", got, err)
+	}
+	if got, err := f.Owner(); err != nil || got != "root" {
+		t.Errorf("Owner() = %q, %v, want root, nil", got, err)
+	}
+	if got, err := f.Group(); err != nil || got != "root" {
+		t.Errorf("Group() = %q, %v, want root, nil", got, err)
+	}
+	if got, err := f.Filetype(); err != nil || got != "file" {
+		t.Errorf("Filetype() = %q, %v, want file, nil", got, err)
+	}
+	if got, err := f.Md5(); err != nil || got != wantMd5 {
+		t.Errorf("Md5() = %q, %v, want %q, nil", got, err, wantMd5)
+	}
+	if got, err := f.Digest("sha256"); err != nil || got != wantSha256 {
+		t.Errorf(`Digest("sha256") = %q, %v, want %q, nil`, got, err, wantSha256)
+	}
+	if got, err := f.ModTime(); err != nil || !got.Equal(modTime) {
+		t.Errorf("ModTime() = %v, %v, want %v, nil", got, err, modTime)
+	}
+	if _, err := f.AccessTime(); err == nil {
+		t.Error("AccessTime() on a mapFS file: want error, got nil")
+	}
+
+	if got, err := newTestFile(t, fsys, "/tmp/dir").Filetype(); err != nil || got != "directory" {
+		t.Errorf("Filetype() of tmp/dir = %q, %v, want directory, nil", got, err)
+	}
+
+	link := newTestFile(t, fsys, "/tmp/link")
+	if got, err := link.Filetype(); err != nil || got != "symlink" {
+		t.Errorf("Filetype() of tmp/link = %q, %v, want symlink, nil", got, err)
+	}
+	if got, err := link.LinkedTo(); err != nil || got != "/tmp/file.txt" {
+		t.Errorf("LinkedTo() = %q, %v, want /tmp/file.txt, nil", got, err)
+	}
+
+	if _, err := newTestFile(t, fsys, "/tmp/missing").Exists(); err != nil {
+		t.Errorf("Exists() on a missing file returned an error: %v", err)
+	}
+	if exists, _ := newTestFile(t, fsys, "/tmp/missing").Exists(); exists {
+		t.Error("Exists() on a missing file = true, want false")
+	}
+}
+
+func TestDefFileTarFS(t *testing.T) {
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	accessTime := modTime.Add(time.Hour)
+	changeTime := modTime.Add(2 * time.Hour)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeTarFile(t, tw, &tar.Header{
+		Name:       "file.txt",
+		Typeflag:   tar.TypeReg,
+		Mode:       0640,
+		Uid:        0,
+		Gid:        0,
+		Size:       int64(len(content)),
+		ModTime:    modTime,
+		AccessTime: accessTime,
+		ChangeTime: changeTime,
+		Format:     tar.FormatPAX,
+	}, []byte(content))
+	writeTarFile(t, tw, &tar.Header{
+		Name:     "link",
+		Typeflag: tar.TypeSymlink,
+		Linkname: "file.txt",
+		Mode:     0777,
+	}, nil)
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tw.Close() = %v", err)
+	}
+
+	fsys, err := NewTarFS(&buf)
+	if err != nil {
+		t.Fatalf("NewTarFS() = %v", err)
+	}
+
+	f := newTestFile(t, fsys, "/file.txt")
+	if got, err := f.Mode(); err != nil || got != "0640" {
+		t.Errorf("Mode() = %q, %v, want 0640, nil", got, err)
+	}
+	if got, err := f.Owner(); err != nil || got != "root" {
+		t.Errorf("Owner() = %q, %v, want root, nil", got, err)
+	}
+	if got, err := f.Md5(); err != nil || got != wantMd5 {
+		t.Errorf("Md5() = %q, %v, want %q, nil", got, err, wantMd5)
+	}
+	if got, err := f.ModTime(); err != nil || !got.Equal(modTime) {
+		t.Errorf("ModTime() = %v, %v, want %v, nil", got, err, modTime)
+	}
+	if got, err := f.AccessTime(); err != nil || !got.Equal(accessTime) {
+		t.Errorf("AccessTime() = %v, %v, want %v, nil", got, err, accessTime)
+	}
+	if got, err := f.ChangeTime(); err != nil || !got.Equal(changeTime) {
+		t.Errorf("ChangeTime() = %v, %v, want %v, nil", got, err, changeTime)
+	}
+
+	link := newTestFile(t, fsys, "/link")
+	if got, err := link.Filetype(); err != nil || got != "symlink" {
+		t.Errorf("Filetype() = %q, %v, want symlink, nil", got, err)
+	}
+	if got, err := link.LinkedTo(); err != nil || got != "file.txt" {
+		t.Errorf("LinkedTo() = %q, %v, want file.txt, nil", got, err)
+	}
+}
+
+func writeTarFile(t *testing.T, tw *tar.Writer, hdr *tar.Header, data []byte) {
+	t.Helper()
+	if err := tw.WriteHeader(hdr); err != nil {
+		t.Fatalf("tw.WriteHeader(%q) = %v", hdr.Name, err)
+	}
+	if len(data) > 0 {
+		if _, err := tw.Write(data); err != nil {
+			t.Fatalf("tw.Write(%q) = %v", hdr.Name, err)
+		}
+	}
+}
+
+func TestDefFileZipFS(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	fh := &zip.FileHeader{Name: "file.txt", Method: zip.Store}
+	fh.SetMode(0640)
+	w, err := zw.CreateHeader(fh)
+	if err != nil {
+		t.Fatalf("zw.CreateHeader() = %v", err)
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		t.Fatalf("writing zip entry: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zw.Close() = %v", err)
+	}
+
+	fsys, err := NewZipFS(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewZipFS() = %v", err)
+	}
+
+	f := newTestFile(t, fsys, "/file.txt")
+	if got, err := f.Mode(); err != nil || got != "0640" {
+		t.Errorf("Mode() = %q, %v, want 0640, nil", got, err)
+	}
+	if got, err := f.Filetype(); err != nil || got != "file" {
+		t.Errorf("Filetype() = %q, %v, want file, nil", got, err)
+	}
+	if got, err := f.Md5(); err != nil || got != wantMd5 {
+		t.Errorf("Md5() = %q, %v, want %q, nil", got, err, wantMd5)
+	}
+	if _, err := f.AccessTime(); err == nil {
+		t.Error("AccessTime() on a zipFS file: want error, got nil")
+	}
+}
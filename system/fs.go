@@ -0,0 +1,65 @@
+package system
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// FS is the root filesystem a File resolves paths against. hostFS (the
+// live operating system) is the default; tarFS, zipFS, and the 9P-backed
+// ninepFS let the same DefFile logic run against an archive or a remote
+// target instead. mapFS backs tests.
+type FS interface {
+	fs.FS
+}
+
+// StatFS is implemented by filesystems that can stat a path directly,
+// without opening it first. All of goss's FS implementations satisfy it;
+// statOf falls back to Open+Stat for a plain fs.FS that doesn't.
+type StatFS interface {
+	FS
+	fs.StatFS
+}
+
+// ReadLinkFS is implemented by filesystems that can resolve a symlink's
+// target without following it. fs.FS has no equivalent, since most of its
+// implementations (e.g. embed.FS) never produce symlinks.
+type ReadLinkFS interface {
+	FS
+	Readlink(name string) (string, error)
+}
+
+// fsPath converts an absolute goss path ("/etc/passwd") into the
+// slash-less, rooted form fs.FS requires ("etc/passwd"), per the
+// fs.ValidPath contract.
+func fsPath(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+// statOf stats name on fsys, preferring StatFS and falling back to
+// Open+Stat for filesystems that only implement fs.FS.
+func statOf(fsys FS, name string) (fs.FileInfo, error) {
+	if sfs, ok := fsys.(fs.StatFS); ok {
+		return sfs.Stat(name)
+	}
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat()
+}
+
+// readLinkOf resolves a symlink on fsys, using ReadLinkFS when the
+// filesystem implements it.
+func readLinkOf(fsys FS, name string) (string, error) {
+	if rfs, ok := fsys.(ReadLinkFS); ok {
+		return rfs.Readlink(name)
+	}
+	return "", fmt.Errorf("%s: filesystem does not support reading links", name)
+}
@@ -0,0 +1,27 @@
+package system
+
+import (
+	"io/fs"
+	"os"
+)
+
+// hostFS answers File queries against the live operating system via
+// plain os calls rooted at "/". NewDefFile has no default FS: a caller
+// passes hostFS for the live host, or an archive- or network-backed one.
+type hostFS struct{}
+
+// newHostFS returns the FS used for ordinary, non-virtual-root goss
+// runs.
+func newHostFS() FS { return hostFS{} }
+
+func (hostFS) Open(name string) (fs.File, error) {
+	return os.Open("/" + name)
+}
+
+func (hostFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Lstat("/" + name)
+}
+
+func (hostFS) Readlink(name string) (string, error) {
+	return os.Readlink("/" + name)
+}
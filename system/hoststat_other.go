@@ -0,0 +1,10 @@
+//go:build !unix
+
+package system
+
+// wrapHostStat reports false on platforms with no Unix stat_t, such as
+// Windows; Owner/Group fall back to a "not available" error there and
+// Mode falls back to fi.Mode().Perm().
+func wrapHostStat(sys interface{}) (ownerStat, bool) {
+	return nil, false
+}
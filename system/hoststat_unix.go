@@ -0,0 +1,28 @@
+//go:build unix
+
+package system
+
+import "syscall"
+
+// hostStat adapts the Unix syscall.Stat_t embedded in an os.FileInfo's
+// Sys() to ownerStat, so DefFile can read uid/gid/mode the same way it
+// does for tar/zip-backed files.
+type hostStat struct {
+	*syscall.Stat_t
+}
+
+func (s hostStat) OwnerID() int    { return int(s.Uid) }
+func (s hostStat) GroupID() int    { return int(s.Gid) }
+func (s hostStat) RawMode() uint32 { return uint32(s.Mode) }
+
+// wrapHostStat wraps the Sys() value of an os.FileInfo produced by
+// hostFS. Apart from wrapHostTime in hosttime_linux.go and
+// hosttime_darwin.go, it's the only place in the package that knows
+// about syscall.Stat_t.
+func wrapHostStat(sys interface{}) (ownerStat, bool) {
+	st, ok := sys.(*syscall.Stat_t)
+	if !ok {
+		return nil, false
+	}
+	return hostStat{st}, true
+}
@@ -0,0 +1,25 @@
+//go:build darwin
+
+package system
+
+import (
+	"syscall"
+	"time"
+)
+
+// hostTime adapts Darwin's syscall.Stat_t's Atimespec/Ctimespec fields
+// to timeStat.
+type hostTime struct {
+	*syscall.Stat_t
+}
+
+func (s hostTime) AccessTime() time.Time { return time.Unix(s.Atimespec.Sec, s.Atimespec.Nsec) }
+func (s hostTime) ChangeTime() time.Time { return time.Unix(s.Ctimespec.Sec, s.Ctimespec.Nsec) }
+
+func wrapHostTime(sys interface{}) (timeStat, bool) {
+	st, ok := sys.(*syscall.Stat_t)
+	if !ok {
+		return nil, false
+	}
+	return hostTime{st}, true
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package system
+
+import (
+	"syscall"
+	"time"
+)
+
+// hostTime adapts the Linux syscall.Stat_t's Atim/Ctim fields to
+// timeStat.
+type hostTime struct {
+	*syscall.Stat_t
+}
+
+func (s hostTime) AccessTime() time.Time { return time.Unix(s.Atim.Sec, s.Atim.Nsec) }
+func (s hostTime) ChangeTime() time.Time { return time.Unix(s.Ctim.Sec, s.Ctim.Nsec) }
+
+func wrapHostTime(sys interface{}) (timeStat, bool) {
+	st, ok := sys.(*syscall.Stat_t)
+	if !ok {
+		return nil, false
+	}
+	return hostTime{st}, true
+}
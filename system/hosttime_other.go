@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package system
+
+// wrapHostTime reports false on platforms whose syscall.Stat_t doesn't
+// carry Atim/Ctim in a form we've wired up (e.g. Windows); AccessTime
+// and ChangeTime fall back to a "not available" error there.
+func wrapHostTime(sys interface{}) (timeStat, bool) {
+	return nil, false
+}
@@ -0,0 +1,94 @@
+package system
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"time"
+)
+
+// MapFile is one entry in a mapFS, mirroring testing/fstest.MapFile but
+// carrying the owner/group/link fields goss's File needs.
+type MapFile struct {
+	Data    []byte
+	Mode    fs.FileMode
+	ModTime time.Time
+	Uid     int
+	Gid     int
+	Link    string // non-empty for symlinks; Data is unused
+}
+
+// mapFS is an in-memory FS, used in tests so File behavior can be
+// exercised without touching the real filesystem or an archive.
+type mapFS map[string]*MapFile
+
+// NewMapFS returns an FS backed entirely by the given in-memory files,
+// keyed by their fs.FS-style path (no leading slash).
+func NewMapFS(files map[string]*MapFile) FS {
+	return mapFS(files)
+}
+
+func (m mapFS) lookup(name string) (*MapFile, error) {
+	f, ok := m[path.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return f, nil
+}
+
+func (m mapFS) Open(name string) (fs.File, error) {
+	f, err := m.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return &mapFileHandle{name: path.Base(name), MapFile: f, Reader: bytes.NewReader(f.Data)}, nil
+}
+
+func (m mapFS) Stat(name string) (fs.FileInfo, error) {
+	f, err := m.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return mapFileInfo{name: path.Base(name), MapFile: f}, nil
+}
+
+func (m mapFS) Readlink(name string) (string, error) {
+	f, err := m.lookup(name)
+	if err != nil {
+		return "", err
+	}
+	if f.Link == "" {
+		return "", fmt.Errorf("%s: not a symlink", name)
+	}
+	return f.Link, nil
+}
+
+// mapFileHandle implements fs.File over one MapFile's buffered content.
+type mapFileHandle struct {
+	name string
+	*MapFile
+	*bytes.Reader
+}
+
+func (f *mapFileHandle) Stat() (fs.FileInfo, error) {
+	return mapFileInfo{name: f.name, MapFile: f.MapFile}, nil
+}
+func (f *mapFileHandle) Close() error { return nil }
+
+// mapFileInfo implements fs.FileInfo and ownerStat for a MapFile.
+type mapFileInfo struct {
+	name string
+	*MapFile
+}
+
+func (i mapFileInfo) Name() string       { return i.name }
+func (i mapFileInfo) Size() int64        { return int64(len(i.Data)) }
+func (i mapFileInfo) Mode() fs.FileMode  { return i.MapFile.Mode }
+func (i mapFileInfo) ModTime() time.Time { return i.MapFile.ModTime }
+func (i mapFileInfo) IsDir() bool        { return i.MapFile.Mode.IsDir() }
+func (i mapFileInfo) Sys() interface{}   { return i }
+
+func (i mapFileInfo) OwnerID() int    { return i.Uid }
+func (i mapFileInfo) GroupID() int    { return i.Gid }
+func (i mapFileInfo) RawMode() uint32 { return uint32(i.MapFile.Mode.Perm()) }
@@ -0,0 +1,131 @@
+package system
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/lionkov/go9p/p"
+	"github.com/lionkov/go9p/p/clnt"
+)
+
+// ninepFS answers File queries by walking and reading a 9P export over
+// the network (Twalk/Tstat/Tread), so goss can validate a target machine
+// without an SSH shell or a kernel-level mount. DialNinePFS returns one,
+// for a caller to pass to NewDefFile; nothing in this package selects it
+// on its own.
+type ninepFS struct {
+	clnt *clnt.Clnt
+}
+
+// DialNinePFS dials a 9P server on addr over TCP, attaching as user, and
+// returns an FS backed by its exported root. This package contains no
+// 9P server and no command-line flag that calls this; see the package doc.
+func DialNinePFS(addr, user string) (FS, error) {
+	c, err := clnt.Mount("tcp", addr, "", 8192, user)
+	if err != nil {
+		return nil, fmt.Errorf("9p: dialing %s: %w", addr, err)
+	}
+	return &ninepFS{clnt: c}, nil
+}
+
+func (n *ninepFS) walk(name string) (*clnt.File, error) {
+	f, err := n.clnt.FOpen(path.Join("/", name), p.OREAD)
+	if err != nil {
+		return nil, &fs.PathError{Op: "walk", Path: name, Err: ninepNotExist(err)}
+	}
+	return f, nil
+}
+
+// ninepNotExist wraps err in fs.ErrNotExist when it represents a missing
+// 9P path, so errors.Is(err, fs.ErrNotExist) in DefFile.Exists() works
+// the same way against a ninepFS target as it does against the host or
+// an archive. A 9P2000.u server reports a missing file as *p.Error with
+// Errornum == p.ENOENT; a plain 9P2000 server only has the textual
+// message, so that's checked too.
+func ninepNotExist(err error) error {
+	pErr, ok := err.(*p.Error)
+	if !ok {
+		return err
+	}
+	msg := strings.ToLower(pErr.Err)
+	if pErr.Errornum == p.ENOENT || strings.Contains(msg, "no such file") || strings.Contains(msg, "file not found") {
+		return fmt.Errorf("%w: %s", fs.ErrNotExist, pErr.Err)
+	}
+	return err
+}
+
+func (n *ninepFS) Open(name string) (fs.File, error) {
+	f, err := n.walk(name)
+	if err != nil {
+		return nil, err
+	}
+	return &ninepFile{File: f, name: path.Base(name)}, nil
+}
+
+func (n *ninepFS) Stat(name string) (fs.FileInfo, error) {
+	f, err := n.walk(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dir, err := f.Stat()
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return ninepFileInfo{dir}, nil
+}
+
+// Readlink isn't implemented: dirModeToFS doesn't map 9P's symlink bit,
+// so a ninepFS never reports fs.ModeSymlink, and LinkedTo() on a ninepFS
+// returns readLinkOf's generic "not supported" error.
+
+// ninepFile adapts a clnt.File to fs.File.
+type ninepFile struct {
+	*clnt.File
+	name string
+}
+
+func (f *ninepFile) Stat() (fs.FileInfo, error) {
+	dir, err := f.File.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ninepFileInfo{dir}, nil
+}
+
+// ninepFileInfo adapts a 9P Dir (the Tstat reply) to fs.FileInfo,
+// ownerStat, and timeStat.
+type ninepFileInfo struct {
+	dir *p.Dir
+}
+
+func (i ninepFileInfo) Name() string       { return i.dir.Name }
+func (i ninepFileInfo) Size() int64        { return int64(i.dir.Length) }
+func (i ninepFileInfo) Mode() fs.FileMode  { return dirModeToFS(i.dir.Mode) }
+func (i ninepFileInfo) ModTime() time.Time { return time.Unix(int64(i.dir.Mtime), 0) }
+func (i ninepFileInfo) IsDir() bool        { return i.dir.Mode&p.DMDIR != 0 }
+func (i ninepFileInfo) Sys() interface{}   { return i }
+
+func (i ninepFileInfo) OwnerID() int    { return int(i.dir.Uidnum) }
+func (i ninepFileInfo) GroupID() int    { return int(i.dir.Gidnum) }
+func (i ninepFileInfo) RawMode() uint32 { return i.dir.Mode & 07777 }
+
+func (i ninepFileInfo) AccessTime() time.Time { return time.Unix(int64(i.dir.Atime), 0) }
+
+// ChangeTime reports Mtime: 9P has no ctime-equivalent field, and Dir's
+// Mtime is the closest approximation of "last metadata change" it carries.
+func (i ninepFileInfo) ChangeTime() time.Time { return time.Unix(int64(i.dir.Mtime), 0) }
+
+// dirModeToFS maps the 9P permission/type bits in a Dir.Mode to the
+// subset of fs.FileMode bits goss's Filetype() switches on.
+func dirModeToFS(mode uint32) fs.FileMode {
+	perm := fs.FileMode(mode & 0777)
+	if mode&p.DMDIR != 0 {
+		return perm | fs.ModeDir
+	}
+	return perm
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/coreos/go-systemd/dbus"
@@ -8,48 +9,90 @@ import (
 
 type ServiceDbus struct {
 	service string
-	enabled bool
-	running bool
 	dbus    *dbus.Conn
 }
 
-func NewServiceDbus(service string, system *System) Service {
-	return &ServiceDbus{
-		service: service,
-		dbus:    system.Dbus,
+// NewServiceDbus connects to the system bus, unless service is prefixed
+// "user:" (e.g. "user:gpg-agent"), in which case it connects to the
+// caller's session bus instead, so goss can validate --user systemd
+// units and socket-activated services in rootless containers. It
+// returns an error rather than silently falling back to the system bus
+// when the user bus can't be reached, since querying the wrong bus for
+// a unit that was never meant to live there produces a confusing
+// "unit not found" instead of a clear dial failure. A --user CLI flag
+// that applies this to every service in a goss file, instead of
+// prefixing each one, would live in cmd; see the package doc.
+func NewServiceDbus(service string, system *System) (Service, error) {
+	unitName := service
+	conn := system.Dbus
+	if name, ok := strings.CutPrefix(service, "user:"); ok {
+		userConn, err := dbus.NewUserConnection()
+		if err != nil {
+			return nil, fmt.Errorf("connecting to user bus for %s: %w", service, err)
+		}
+		unitName = name
+		conn = userConn
 	}
+
+	return &ServiceDbus{
+		service: unitName,
+		dbus:    conn,
+	}, nil
 }
 
 func (s *ServiceDbus) Service() string {
 	return s.service
 }
 
+// Enabled reports the unit's UnitFileState, collapsing systemd's
+// "enabled-runtime"/"masked-runtime" variants into "enabled"/"masked" but
+// otherwise passing the raw state ("enabled", "disabled", "masked",
+// "static", "indirect", "alias", ...) straight through, so a masked or
+// static unit no longer reads as plain "disabled".
 func (s *ServiceDbus) Enabled() (interface{}, error) {
-	stateRaw, err := s.dbus.GetUnitProperty(s.service+".service", "UnitFileState")
+	state, err := s.unitProperty("UnitFileState")
 	if err != nil {
-		return false, err
+		return "", err
 	}
-	state := stateRaw.Value.String()
-	state = strings.Trim(state, "\"")
 
-	if state == "enabled" {
-		return true, nil
+	switch state {
+	case "enabled-runtime":
+		return "enabled", nil
+	case "masked-runtime":
+		return "masked", nil
 	}
-
-	return false, nil
+	return state, nil
 }
 
+// Running reports whether the unit's ActiveState is "active", as before;
+// use SubState for the running/exited/dead/failed detail ActiveState
+// alone can't distinguish.
 func (s *ServiceDbus) Running() (interface{}, error) {
-	stateRaw, err := s.dbus.GetUnitProperty(s.service+".service", "ActiveState")
+	state, err := s.unitProperty("ActiveState")
 	if err != nil {
 		return false, err
 	}
-	state := stateRaw.Value.String()
-	state = strings.Trim(state, "\"")
+	return state == "active", nil
+}
 
-	if state == "active" {
-		return true, nil
-	}
+// SubState reports the unit's SubState ("running", "exited", "dead",
+// "failed", ...), which disambiguates ActiveState=="active" between a
+// long-running daemon and a oneshot service that already exited cleanly.
+func (s *ServiceDbus) SubState() (interface{}, error) {
+	return s.unitProperty("SubState")
+}
+
+// LoadState reports the unit's LoadState ("loaded", "not-found",
+// "masked", ...), which is how systemd distinguishes a unit it couldn't
+// find from one that's merely disabled.
+func (s *ServiceDbus) LoadState() (interface{}, error) {
+	return s.unitProperty("LoadState")
+}
 
-	return false, nil
-}
\ No newline at end of file
+func (s *ServiceDbus) unitProperty(name string) (string, error) {
+	raw, err := s.dbus.GetUnitProperty(s.service+".service", name)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(raw.Value.String(), "\""), nil
+}
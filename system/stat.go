@@ -0,0 +1,37 @@
+package system
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"io/fs"
+)
+
+// ownerStat is the information DefFile needs from an fs.FileInfo's Sys()
+// value in order to answer Owner/Group/Mode: a raw uid, gid, and
+// permission bits. hostFS satisfies it via a wrapped syscall.Stat_t,
+// tarFS and zipFS via their archive headers, and mapFS directly. It
+// replaces a hard syscall.Stat_t type assertion, which only exists on
+// Unix and doesn't describe an archive entry.
+type ownerStat interface {
+	OwnerID() int
+	GroupID() int
+	RawMode() uint32
+}
+
+// statOwner extracts ownerStat from fi.Sys(), trying the archive header
+// types directly before falling back to wrapHostStat for a live-host
+// fs.FileInfo. The second return value is false when fsys doesn't carry
+// owner information at all (e.g. a future FS backed by something that
+// has no concept of uid/gid).
+func statOwner(fi fs.FileInfo) (ownerStat, bool) {
+	switch sys := fi.Sys().(type) {
+	case ownerStat:
+		return sys, true
+	case *tar.Header:
+		return tarHeaderStat{sys}, true
+	case *zip.FileHeader:
+		return zipHeaderStat{sys}, true
+	default:
+		return wrapHostStat(sys)
+	}
+}
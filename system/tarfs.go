@@ -0,0 +1,100 @@
+package system
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+)
+
+// tarEntry is one archive member's header and content, read once when
+// the tarFS is constructed.
+type tarEntry struct {
+	header *tar.Header
+	data   []byte
+}
+
+// tarFS answers File queries against the contents of a tar archive
+// without extracting it to disk, so a goss file can assert on a
+// container image layer or release tarball in place. The archive is
+// read fully into memory at construction; lookups thereafter are by
+// path.
+type tarFS struct {
+	entries map[string]*tarEntry
+}
+
+// NewTarFS reads a tar archive from r and returns an FS backed by its
+// contents.
+func NewTarFS(r io.Reader) (FS, error) {
+	tfs := &tarFS{entries: map[string]*tarEntry{}}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		tfs.entries[path.Clean(hdr.Name)] = &tarEntry{header: hdr, data: data}
+	}
+	return tfs, nil
+}
+
+func (t *tarFS) lookup(name string) (*tarEntry, error) {
+	e, ok := t.entries[path.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return e, nil
+}
+
+func (t *tarFS) Open(name string) (fs.File, error) {
+	e, err := t.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return &tarFile{entry: e, Reader: bytes.NewReader(e.data)}, nil
+}
+
+func (t *tarFS) Stat(name string) (fs.FileInfo, error) {
+	e, err := t.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return e.header.FileInfo(), nil
+}
+
+func (t *tarFS) Readlink(name string) (string, error) {
+	e, err := t.lookup(name)
+	if err != nil {
+		return "", err
+	}
+	return e.header.Linkname, nil
+}
+
+// tarFile implements fs.File over one archive entry's buffered content.
+type tarFile struct {
+	entry *tarEntry
+	*bytes.Reader
+}
+
+func (f *tarFile) Stat() (fs.FileInfo, error) { return f.entry.header.FileInfo(), nil }
+func (f *tarFile) Close() error               { return nil }
+
+// tarHeaderStat adapts a tar.Header to ownerStat and timeStat; uid/gid/
+// mode/atime/ctime are all preserved on the header.
+type tarHeaderStat struct{ *tar.Header }
+
+func (s tarHeaderStat) OwnerID() int    { return s.Uid }
+func (s tarHeaderStat) GroupID() int    { return s.Gid }
+func (s tarHeaderStat) RawMode() uint32 { return uint32(s.Mode) }
+
+func (s tarHeaderStat) AccessTime() time.Time { return s.Header.AccessTime }
+func (s tarHeaderStat) ChangeTime() time.Time { return s.Header.ChangeTime }
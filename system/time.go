@@ -0,0 +1,33 @@
+package system
+
+import (
+	"archive/tar"
+	"io/fs"
+	"time"
+)
+
+// timeStat is the access/change time information DefFile needs beyond
+// fs.FileInfo.ModTime(), which every FS already provides. hostFS
+// supplies it from the platform stat_t and tarFS from tar.Header's
+// AccessTime/ChangeTime fields, which are preserved on the header the
+// same way Uid/Gid/Mode are. zipFS and mapFS have neither, so
+// AccessTime/ChangeTime report an error there rather than a made-up
+// zero time.
+type timeStat interface {
+	AccessTime() time.Time
+	ChangeTime() time.Time
+}
+
+// The resource/matcher layer that accepts an RFC3339 timestamp, a >/<
+// comparison, or a duration-relative form like "< 24h" for these isn't
+// part of this package; see the package doc.
+func statTime(fi fs.FileInfo) (timeStat, bool) {
+	switch sys := fi.Sys().(type) {
+	case timeStat:
+		return sys, true
+	case *tar.Header:
+		return tarHeaderStat{sys}, true
+	default:
+		return wrapHostTime(sys)
+	}
+}
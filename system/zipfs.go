@@ -0,0 +1,85 @@
+package system
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+)
+
+// zipFS answers File queries against the contents of a zip archive. The
+// central directory is read once at construction; file data is
+// decompressed lazily on Open.
+type zipFS struct {
+	reader *zip.Reader
+	byName map[string]*zip.File
+}
+
+// NewZipFS reads a zip archive's central directory from r (size bytes
+// long) and returns an FS backed by its contents.
+func NewZipFS(r io.ReaderAt, size int64) (FS, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	zfs := &zipFS{reader: zr, byName: map[string]*zip.File{}}
+	for _, f := range zr.File {
+		zfs.byName[path.Clean(f.Name)] = f
+	}
+	return zfs, nil
+}
+
+func (z *zipFS) lookup(name string) (*zip.File, error) {
+	f, ok := z.byName[path.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return f, nil
+}
+
+func (z *zipFS) Open(name string) (fs.File, error) {
+	// zip.Reader already implements fs.FS; *zip.File.Open returns a plain
+	// io.ReadCloser, which doesn't satisfy fs.File (no Stat), so we go
+	// through the reader rather than the individual *zip.File here.
+	return z.reader.Open(path.Clean(name))
+}
+
+func (z *zipFS) Stat(name string) (fs.FileInfo, error) {
+	f, err := z.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return f.FileInfo(), nil
+}
+
+// Readlink resolves a zip symlink by reading its entry, since the zip
+// format stores a symlink's target as the entry's content rather than in
+// the header.
+func (z *zipFS) Readlink(name string) (string, error) {
+	f, err := z.lookup(name)
+	if err != nil {
+		return "", err
+	}
+	if f.Mode()&fs.ModeSymlink == 0 {
+		return "", fmt.Errorf("%s: not a symlink", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	dst, err := io.ReadAll(rc)
+	if err != nil {
+		return "", err
+	}
+	return string(dst), nil
+}
+
+// zipHeaderStat adapts a zip.FileHeader to ownerStat. The zip format has
+// no portable uid/gid field, so OwnerID/GroupID always report 0.
+type zipHeaderStat struct{ *zip.FileHeader }
+
+func (s zipHeaderStat) OwnerID() int    { return 0 }
+func (s zipHeaderStat) GroupID() int    { return 0 }
+func (s zipHeaderStat) RawMode() uint32 { return uint32(s.Mode().Perm()) }